@@ -0,0 +1,20 @@
+package ring
+
+import "math"
+
+// boundedCapacity computes the per-host load cap used by GetNodeBounded:
+// ceil(avg * factor), where avg is totalLoad spread evenly across numHosts.
+// The cap is floored at 1 so a freshly created ring (totalLoad == 0) doesn't
+// reject its own primary host before any load has been recorded.
+func boundedCapacity(totalLoad int64, numHosts int, factor float64) int64 {
+	if numHosts == 0 {
+		return 0
+	}
+
+	avg := float64(totalLoad) / float64(numHosts)
+	cap := int64(math.Ceil(avg * factor))
+	if cap < 1 {
+		cap = 1
+	}
+	return cap
+}
@@ -0,0 +1,74 @@
+package ring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// with no load recorded, GetNodeBounded should behave like GetNode.
+func TestHashRingGetNodeBoundedNoLoad(t *testing.T) {
+	r := NewHashRing()
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+	r.Add("localhost:7002", 20)
+
+	data := []byte("golang")
+	assert.Equal(t, r.GetNode(data), r.GetNodeBounded(data, 1.25))
+}
+
+// once the primary's load reaches its cap, GetNodeBounded should route to a
+// different, under-cap host rather than overloading the primary.
+func TestHashRingGetNodeBoundedSpillsOver(t *testing.T) {
+	r := NewHashRing()
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+	r.Add("localhost:7002", 20)
+
+	data := []byte("golang")
+	primary := r.GetNode(data).GetHost()
+
+	// push the primary well past any reasonable cap
+	for i := 0; i < 1000; i++ {
+		r.Inc(primary)
+	}
+
+	spilled := r.GetNodeBounded(data, 1.25)
+	assert.NotEqual(t, primary, spilled.GetHost())
+}
+
+// Inc/Dec/Loads should track per-host load accurately.
+func TestHashRingLoads(t *testing.T) {
+	r := NewHashRing()
+	r.Add("localhost:7000", 20)
+
+	r.Inc("localhost:7000")
+	r.Inc("localhost:7000")
+	r.Dec("localhost:7000")
+
+	loads := r.Loads()
+	assert.Equal(t, int64(1), loads["localhost:7000"])
+
+	// decrementing below zero should be a no-op
+	r.Dec("localhost:7000")
+	r.Dec("localhost:7000")
+	assert.Equal(t, int64(0), r.Loads()["localhost:7000"])
+}
+
+// the Rendezvous ring should honor the same bounded-load contract.
+func TestRendezvousGetNodeBoundedSpillsOver(t *testing.T) {
+	r := NewRendezvousRing()
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+	r.Add("localhost:7002", 20)
+
+	data := []byte("golang")
+	primary := r.GetNode(data).GetHost()
+
+	for i := 0; i < 1000; i++ {
+		r.Inc(primary)
+	}
+
+	spilled := r.GetNodeBounded(data, 1.25)
+	assert.NotEqual(t, primary, spilled.GetHost())
+}
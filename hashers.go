@@ -0,0 +1,84 @@
+package ring
+
+// package imports
+import (
+	stdhash "hash"
+	"hash/fnv"
+	"io"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/spaolacci/murmur3"
+)
+
+// Hasher64 hashes a key into a 64-bit value used to place it on the ring.
+// Implementations must be safe for concurrent use.
+type Hasher64 func(data []byte) uint64
+
+// fnvPool recycles fnv.New64a hashers so the default hasher doesn't allocate
+// one per call.
+var fnvPool = sync.Pool{
+	New: func() interface{} {
+		return fnv.New64a()
+	},
+}
+
+// FNV1aHash hashes data using 64-bit FNV-1a. This is the default hasher used
+// by NewHashRing.
+func FNV1aHash(data []byte) uint64 {
+	hasher := fnvPool.Get().(stdhash.Hash64)
+	hasher.Reset()
+	hasher.Write(data)
+	sum := hasher.Sum64()
+	fnvPool.Put(hasher)
+	return sum
+}
+
+// fnv64aOffset and fnv64aPrime are the 64-bit FNV-1a constants, mirroring
+// the unexported ones hash/fnv computes the same hash with internally.
+const (
+	fnv64aOffset = 14695981039346656037
+	fnv64aPrime  = 1099511628211
+)
+
+// fnvHashString hashes s using 64-bit FNV-1a without ever converting it to a
+// []byte: hash/fnv's sum64a only implements Write([]byte), not
+// io.StringWriter, so routing a string through it (even via io.WriteString)
+// still pays for the []byte(s) copy. Indexing the string byte-by-byte avoids
+// that copy entirely.
+func fnvHashString(s string) uint64 {
+	h := uint64(fnv64aOffset)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnv64aPrime
+	}
+	return h
+}
+
+// fnvHashReader hashes the remaining contents of r using 64-bit FNV-1a,
+// streaming through a pooled hasher so large or unbounded readers don't need
+// to be materialized into memory up front.
+func fnvHashReader(r io.Reader) (uint64, error) {
+	hasher := fnvPool.Get().(stdhash.Hash64)
+	hasher.Reset()
+	_, err := io.Copy(hasher, r)
+	sum := hasher.Sum64()
+	fnvPool.Put(hasher)
+	if err != nil {
+		return 0, err
+	}
+	return sum, nil
+}
+
+// XXHash hashes data using xxhash, a non-cryptographic hash favored for its
+// speed and wide support across other languages' consistent-hash clients.
+func XXHash(data []byte) uint64 {
+	return xxhash.Sum64(data)
+}
+
+// Murmur3Hash hashes data using 64-bit Murmur3. Useful when a ring needs to
+// agree on bucket placement with a Python or Java client, both of which
+// commonly ship Murmur3 implementations.
+func Murmur3Hash(data []byte) uint64 {
+	return murmur3.Sum64(data)
+}
@@ -0,0 +1,23 @@
+package ring
+
+import "fmt"
+
+// movedSampleSize is the number of synthetic keys Moved hashes against both
+// rings when estimating the remapped fraction.
+const movedSampleSize = 100000
+
+// Moved samples movedSampleSize synthetic keys against oldRing and newRing
+// and returns the fraction whose owning host differs between the two,
+// e.g. a return value of 0.2 means roughly 1 in 5 sampled keys moved.
+// It's meant to be called before/after a Remove, Add or Update to sanity
+// check that a rebalance only moved as many keys as expected.
+func Moved(oldRing, newRing Ring) float64 {
+	var moved int
+	for i := 0; i < movedSampleSize; i++ {
+		key := []byte(fmt.Sprint(i))
+		if oldRing.GetNode(key).GetHost() != newRing.GetNode(key).GetHost() {
+			moved++
+		}
+	}
+	return float64(moved) / float64(movedSampleSize)
+}
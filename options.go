@@ -0,0 +1,63 @@
+package ring
+
+// defaultCapacity is the initial virtual node capacity used when not
+// overridden via WithCapacity.
+const defaultCapacity = 16
+
+// hashRingOptions holds the configuration assembled by Option functions
+// before a hashRing is constructed.
+type hashRingOptions struct {
+	keyHasher       Hasher64
+	nodeHasher      Hasher64
+	capacity        int
+	customKeyHasher bool
+}
+
+// Option configures a hashRing built by NewHashRingWithOptions.
+type Option func(*hashRingOptions)
+
+// WithKeyHasher sets the Hasher64 used to hash lookup keys passed to GetNode.
+func WithKeyHasher(hasher Hasher64) Option {
+	return func(o *hashRingOptions) {
+		o.keyHasher = hasher
+		o.customKeyHasher = true
+	}
+}
+
+// WithNodeHasher sets the Hasher64 used to hash virtual node labels when
+// hosts are added to the ring.
+func WithNodeHasher(hasher Hasher64) Option {
+	return func(o *hashRingOptions) {
+		o.nodeHasher = hasher
+	}
+}
+
+// WithCapacity sets the initial virtual node capacity of the ring, avoiding
+// slice growth as the first hosts are added.
+func WithCapacity(capacity int) Option {
+	return func(o *hashRingOptions) {
+		o.capacity = capacity
+	}
+}
+
+// NewHashRingWithOptions creates a new hash ring configured by the given
+// Options. Unset options default to FNV-1a hashing and a capacity of 16,
+// matching NewHashRing.
+func NewHashRingWithOptions(opts ...Option) Ring {
+	o := &hashRingOptions{
+		keyHasher:  FNV1aHash,
+		nodeHasher: FNV1aHash,
+		capacity:   defaultCapacity,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &hashRing{
+		nodes:            make(nodeList, 0, o.capacity),
+		keyHasher:        o.keyHasher,
+		nodeHasher:       o.nodeHasher,
+		defaultKeyHasher: !o.customKeyHasher,
+		loads:            make(map[string]int64),
+	}
+}
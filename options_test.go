@@ -0,0 +1,50 @@
+package ring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the zero-option builder should behave exactly like NewHashRing
+func TestNewHashRingWithOptionsDefaults(t *testing.T) {
+	r := NewHashRingWithOptions().(*hashRing)
+
+	assert.Equal(t, defaultCapacity, cap(r.nodes))
+	assert.Equal(t, 0, r.Size())
+}
+
+// WithCapacity should preallocate the node slice
+func TestWithCapacity(t *testing.T) {
+	r := NewHashRingWithOptions(WithCapacity(64)).(*hashRing)
+
+	assert.Equal(t, 64, cap(r.nodes))
+}
+
+// a custom key/node hasher should be used instead of the FNV-1a default
+func TestWithKeyAndNodeHasher(t *testing.T) {
+	var calls int
+	countingHasher := func(data []byte) uint64 {
+		calls++
+		return XXHash(data)
+	}
+
+	r := NewHashRingWithOptions(
+		WithKeyHasher(countingHasher),
+		WithNodeHasher(countingHasher),
+	)
+	r.Add("localhost:7000", 5)
+	assert.Equal(t, 5, calls)
+
+	r.GetNode([]byte("golang"))
+	assert.Equal(t, 6, calls)
+}
+
+// built-in hashers should be deterministic for the same input
+func TestBuiltinHashersAreDeterministic(t *testing.T) {
+	data := []byte("golang")
+
+	assert.Equal(t, FNV1aHash(data), FNV1aHash(data))
+	assert.Equal(t, XXHash(data), XXHash(data))
+	assert.Equal(t, Murmur3Hash(data), Murmur3Hash(data))
+}
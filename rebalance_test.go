@@ -0,0 +1,92 @@
+package ring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// removing a host should only reassign the keys it used to own (plus the
+// keys of whichever surviving bucket gets relocated into its place), not the
+// whole ring: out of 5 equally-sized hosts, removing one should remap a
+// minority of keys, not all of them.
+func TestHashRingRemoveMinimalMovement(t *testing.T) {
+	before := NewHashRing()
+	before.Add("localhost:7000", 20)
+	before.Add("localhost:7001", 20)
+	before.Add("localhost:7002", 20)
+	before.Add("localhost:7003", 20)
+	before.Add("localhost:7004", 20)
+
+	after := NewHashRing()
+	after.Add("localhost:7000", 20)
+	after.Add("localhost:7001", 20)
+	after.Add("localhost:7002", 20)
+	after.Add("localhost:7003", 20)
+	after.Add("localhost:7004", 20)
+	after.Remove("localhost:7004")
+
+	// removing 1 host out of 5 costs on the order of twice its 1/5 share
+	// (see the Remove doc comment), comfortably under a full reshuffle.
+	moved := Moved(before, after)
+	assert.InDelta(t, 0.32, moved, 0.1)
+
+	hr := after.(*hashRing)
+	for _, n := range hr.nodes {
+		assert.NotEqual(t, "localhost:7004", n.GetHost())
+	}
+}
+
+// Update should grow or shrink a host's virtual node count without
+// disturbing the other hosts any more than an equivalent Remove/Add would.
+func TestHashRingUpdate(t *testing.T) {
+	r := NewHashRing().(*hashRing)
+	r.Add("localhost:7000", 10)
+	r.Add("localhost:7001", 10)
+
+	r.Update("localhost:7000", 30)
+	assert.Equal(t, 40, r.Size())
+
+	var count int
+	for _, n := range r.nodes {
+		if n.host == "localhost:7000" {
+			count++
+		}
+	}
+	assert.Equal(t, 30, count)
+
+	r.Update("localhost:7000", 5)
+	assert.Equal(t, 15, r.Size())
+}
+
+// Moved should report ~0 for identical rings and ~1 when every host changed.
+func TestMoved(t *testing.T) {
+	a := NewHashRing()
+	a.Add("localhost:7000", 20)
+	a.Add("localhost:7001", 20)
+
+	b := NewHashRing()
+	b.Add("localhost:7000", 20)
+	b.Add("localhost:7001", 20)
+
+	assert.Equal(t, float64(0), Moved(a, b))
+
+	c := NewHashRing()
+	c.Add("localhost:9000", 20)
+	c.Add("localhost:9001", 20)
+
+	assert.Equal(t, float64(1), Moved(a, c))
+}
+
+// sanity check that removeLocked's swap-to-tail leaves no gaps or duplicates
+func TestHashRingRemoveAll(t *testing.T) {
+	r := NewHashRing().(*hashRing)
+	for i := 0; i < 5; i++ {
+		r.Add(fmt.Sprint("localhost:", 7000+i), 10)
+	}
+	for i := 0; i < 5; i++ {
+		r.Remove(fmt.Sprint("localhost:", 7000+i))
+	}
+	assert.Equal(t, 0, r.Size())
+}
@@ -0,0 +1,268 @@
+package ring
+
+// package imports
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// --------------------
+//   Rendezvous Ring
+// --------------------
+
+// rendezvousRing implements Ring using Highest Random Weight (Rendezvous) hashing.
+// Unlike the Jump Hash based hashRing, a key's owner is recomputed by scoring every
+// node against the key rather than by locating the key's position on a sorted ring.
+// This trades Jump Hash's O(log n) lookups for the ability to remove any host, not just the tail,
+// without reshuffling keys owned by the other hosts.
+type rendezvousRing struct {
+	sync.RWMutex
+	nodes map[string]node
+
+	// replicaHashes holds each host's virtual replica hashes, computed once in
+	// Add/Update rather than recomputed (via fmt.Sprint + hash) on every
+	// GetNode/GetNodes call.
+	replicaHashes map[string][]uint64
+
+	loadMu sync.Mutex
+	loads  map[string]int64
+}
+
+// NewRendezvousRing creates a new Ring backed by Rendezvous (HRW) hashing.
+func NewRendezvousRing() Ring {
+	return &rendezvousRing{
+		nodes:         make(map[string]node),
+		replicaHashes: make(map[string][]uint64),
+		loads:         make(map[string]int64),
+	}
+}
+
+// Add registers a host with the ring. size acts as the number of virtual
+// replicas scored for the host on every GetNode call, mirroring hashRing's
+// notion of virtual nodes.
+func (r *rendezvousRing) Add(host string, size int) {
+	r.Lock()
+	defer r.Unlock()
+	r.nodes[host] = node{host: host, size: size}
+	r.replicaHashes[host] = replicaHashesFor(host, size)
+}
+
+// replicaHashesFor precomputes the hash for each of a host's virtual
+// replicas, using the same label format as hashRing.addLocked. The index here
+// counts from 0 for each host, whereas hashRing.addLocked uses the virtual
+// node's slot in the whole ring.
+func replicaHashesFor(host string, size int) []uint64 {
+	hashes := make([]uint64, size)
+	for i := 0; i < size; i++ {
+		// hash: 0:localhost:7000:0 - same format as the virtual node labels used by hashRing.Add
+		hashes[i] = hash([]byte(fmt.Sprint(i, ":", host, ":", i)))
+	}
+	return hashes
+}
+
+// Remove drops a host from the ring. Because Rendezvous hashing scores every
+// host independently for each lookup, removing a host only remaps the keys
+// that host used to own.
+func (r *rendezvousRing) Remove(host string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.nodes, host)
+	delete(r.replicaHashes, host)
+
+	r.loadMu.Lock()
+	delete(r.loads, host)
+	r.loadMu.Unlock()
+}
+
+// Update changes the number of virtual replicas registered for host. As with
+// Remove, Rendezvous hashing scores hosts independently, so only keys that
+// were (or will be) scored against host are affected.
+func (r *rendezvousRing) Update(host string, size int) {
+	r.Add(host, size)
+}
+
+// Size returns the total number of virtual replicas across all hosts.
+func (r *rendezvousRing) Size() int {
+	r.RLock()
+	defer r.RUnlock()
+
+	var size int
+	for _, n := range r.nodes {
+		size += n.size
+	}
+	return size
+}
+
+// GetNode returns the host whose virtual replica scores the highest weight
+// for data. Each host's replicas are scored separately so that a host with a
+// larger size is proportionally more likely to win.
+func (r *rendezvousRing) GetNode(data []byte) Node {
+	r.RLock()
+	defer r.RUnlock()
+	return r.bestNodeLocked(hash(data))
+}
+
+// GetNodeString is equivalent to GetNode([]byte(key)) but avoids the
+// string-to-[]byte conversion.
+func (r *rendezvousRing) GetNodeString(key string) Node {
+	r.RLock()
+	defer r.RUnlock()
+	return r.bestNodeLocked(fnvHashString(key))
+}
+
+// GetNodeReader hashes the remaining contents of rd and returns its node. See
+// the Ring interface for details.
+func (r *rendezvousRing) GetNodeReader(rd io.Reader) (Node, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	keyHash, err := fnvHashReader(rd)
+	if err != nil {
+		return nil, err
+	}
+	return r.bestNodeLocked(keyHash), nil
+}
+
+// bestNodeLocked scores every host's virtual replicas against keyHash and
+// returns the highest-weighted host. Callers must already hold at least a
+// read lock on r.
+func (r *rendezvousRing) bestNodeLocked(keyHash uint64) node {
+	var best node
+	var bestWeight uint64
+	found := false
+
+	for host, n := range r.nodes {
+		for _, replicaHash := range r.replicaHashes[host] {
+			w := rendezvousWeight(keyHash ^ replicaHash)
+			if !found || w > bestWeight {
+				bestWeight = w
+				best = n
+				found = true
+			}
+		}
+	}
+	return best
+}
+
+// GetNodes returns up to n distinct physical hosts that own data, ranked by
+// descending HRW weight. See the Ring interface for details.
+func (r *rendezvousRing) GetNodes(data []byte, n int) []Node {
+	return r.GetNodesFiltered(data, n, nil)
+}
+
+// GetNodesFiltered returns up to n distinct physical hosts that own data and
+// satisfy filter, ranked by descending HRW weight. See the Ring interface
+// for details.
+func (r *rendezvousRing) GetNodesFiltered(data []byte, n int, filter func(Node) bool) []Node {
+	r.RLock()
+	defer r.RUnlock()
+
+	if len(r.nodes) == 0 || n <= 0 {
+		return nil
+	}
+
+	keyHash := hash(data)
+	ranked := make([]node, 0, len(r.nodes))
+	weights := make(map[string]uint64, len(r.nodes))
+
+	for host, nd := range r.nodes {
+		if filter != nil && !filter(nd) {
+			continue
+		}
+
+		var best uint64
+		for _, replicaHash := range r.replicaHashes[host] {
+			if w := rendezvousWeight(keyHash ^ replicaHash); w > best {
+				best = w
+			}
+		}
+		ranked = append(ranked, nd)
+		weights[host] = best
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		return weights[ranked[i].host] > weights[ranked[j].host]
+	})
+
+	if n > len(ranked) {
+		n = len(ranked)
+	}
+
+	nodes := make([]Node, n)
+	for i := 0; i < n; i++ {
+		nodes[i] = ranked[i]
+	}
+	return nodes
+}
+
+// Inc records one unit of load against host, for use with GetNodeBounded.
+func (r *rendezvousRing) Inc(host string) {
+	r.loadMu.Lock()
+	defer r.loadMu.Unlock()
+	r.loads[host]++
+}
+
+// Dec removes one unit of load previously recorded against host.
+func (r *rendezvousRing) Dec(host string) {
+	r.loadMu.Lock()
+	defer r.loadMu.Unlock()
+	if r.loads[host] > 0 {
+		r.loads[host]--
+	}
+}
+
+// Loads returns a snapshot of the load recorded against every host.
+func (r *rendezvousRing) Loads() map[string]int64 {
+	r.loadMu.Lock()
+	defer r.loadMu.Unlock()
+
+	loads := make(map[string]int64, len(r.loads))
+	for host, load := range r.loads {
+		loads[host] = load
+	}
+	return loads
+}
+
+// GetNodeBounded is a bounded-load variant of GetNode: hosts are ranked by
+// descending HRW weight, same as GetNodes, and the highest-ranked host under
+// the load cap wins. See the Ring interface for details.
+func (r *rendezvousRing) GetNodeBounded(data []byte, factor float64) Node {
+	r.RLock()
+	hostCount := len(r.nodes)
+	r.RUnlock()
+
+	ranked := r.GetNodes(data, hostCount)
+	if len(ranked) == 0 {
+		return nil
+	}
+
+	r.loadMu.Lock()
+	var total int64
+	for _, load := range r.loads {
+		total += load
+	}
+	r.loadMu.Unlock()
+
+	cap := boundedCapacity(total, len(ranked), factor)
+
+	for _, candidate := range ranked {
+		r.loadMu.Lock()
+		load := r.loads[candidate.GetHost()]
+		r.loadMu.Unlock()
+
+		if load < cap {
+			return candidate
+		}
+	}
+
+	// every host is at or above cap; fall back to the primary
+	return ranked[0]
+}
+
+// rendezvousWeight mixes a combined key/node hash into a 64-bit weight using
+// the standard Wang/xorshift-style avalanche popularized by HRW implementations.
+func rendezvousWeight(h uint64) uint64 {
+	return h * (2862933555777941757*h + 1)
+}
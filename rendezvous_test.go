@@ -0,0 +1,101 @@
+package ring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GaryBoone/GoStats/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+// validates nodes (+ virtual nodes have been registered)
+func TestRendezvousAddNode(t *testing.T) {
+	host := "localhost:7000"
+
+	r := NewRendezvousRing()
+	r.Add(host, 5)
+
+	assert.Equal(t, 5, r.Size())
+}
+
+// ensures all physical nodes are distributed evenly within e=0.0001
+func TestRendezvousNodeDistribution(t *testing.T) {
+	r := NewRendezvousRing()
+
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+	r.Add("localhost:7002", 20)
+	r.Add("localhost:7003", 20)
+	r.Add("localhost:7004", 20)
+
+	var d stats.Stats
+	nodes := make(map[string]int)
+	nodes["localhost:7000"] = 0
+	nodes["localhost:7001"] = 0
+	nodes["localhost:7002"] = 0
+	nodes["localhost:7003"] = 0
+	nodes["localhost:7004"] = 0
+
+	var COUNT int = 1e6
+	for i := 0; i < COUNT; i++ {
+		host := r.GetNode([]byte(fmt.Sprint(i))).GetHost()
+		nodes[host]++
+	}
+
+	var avg = float64(COUNT) / float64(5)
+	for _, value := range nodes {
+		d.Update(float64(value))
+	}
+	assert.InEpsilon(t, avg, d.Mean(), 0.0001)
+}
+
+// removing a host should only affect keys that host used to own
+func TestRendezvousRemove(t *testing.T) {
+	r := NewRendezvousRing()
+
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+	r.Add("localhost:7002", 20)
+
+	assert.Equal(t, 60, r.Size())
+
+	r.Remove("localhost:7001")
+
+	assert.Equal(t, 40, r.Size())
+	for i := 0; i < 1000; i++ {
+		assert.NotEqual(t, "localhost:7001", r.GetNode([]byte(fmt.Sprint(i))).GetHost())
+	}
+}
+
+// closure function for benchmarking multiple clusters, analogous to baselineBenchmark
+func rendezvousBenchmark(hosts, vnodes int) func(b *testing.B) {
+	r := NewRendezvousRing()
+	var startPort = 7000
+	for i := startPort; i < hosts+startPort; i++ {
+		r.Add(fmt.Sprint("localhost:", i), vnodes)
+	}
+	data := []byte("golang")
+
+	return func(b *testing.B) {
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			r.GetNode(data)
+		}
+	}
+}
+
+// 5 Nodes
+func BenchmarkRendezvousGetNode_5_Nodes(b *testing.B) {
+	rendezvousBenchmark(5, 1)(b)
+}
+
+// 5 Nodes with 5 Virtual Nodes each
+func BenchmarkRendezvousGetNode_25_Nodes(b *testing.B) {
+	rendezvousBenchmark(5, 5)(b)
+}
+
+// 20 Nodes with 5 Virtual Nodes each
+func BenchmarkRendezvousGetNode_100_Nodes(b *testing.B) {
+	rendezvousBenchmark(20, 5)(b)
+}
@@ -0,0 +1,95 @@
+package ring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// for n <= the number of unique hosts, GetNodes should return exactly n
+// distinct hosts.
+func TestHashRingGetNodesDistinctHosts(t *testing.T) {
+	r := NewHashRing()
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+	r.Add("localhost:7002", 20)
+	r.Add("localhost:7003", 20)
+	r.Add("localhost:7004", 20)
+
+	nodes := r.GetNodes([]byte("golang"), 3)
+	assert.Len(t, nodes, 3)
+
+	seen := make(map[string]bool)
+	for _, n := range nodes {
+		assert.False(t, seen[n.GetHost()])
+		seen[n.GetHost()] = true
+	}
+}
+
+// GetNodes should be capped at the number of unique hosts available.
+func TestHashRingGetNodesExhaustsRing(t *testing.T) {
+	r := NewHashRing()
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+
+	nodes := r.GetNodes([]byte("golang"), 10)
+	assert.Len(t, nodes, 2)
+}
+
+// the primary returned by GetNodes must always match GetNode, including
+// after more hosts are added to the ring.
+func TestHashRingGetNodesPrimaryStable(t *testing.T) {
+	r := NewHashRing()
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+	r.Add("localhost:7002", 20)
+
+	data := []byte("golang")
+	primary := r.GetNode(data)
+	assert.Equal(t, primary, r.GetNodes(data, 3)[0])
+
+	r.Add("localhost:7003", 20)
+	r.Add("localhost:7004", 20)
+
+	primary = r.GetNode(data)
+	assert.Equal(t, primary, r.GetNodes(data, 3)[0])
+}
+
+// GetNodesFiltered should skip hosts rejected by the filter, e.g. hosts in
+// an already-represented rack.
+func TestHashRingGetNodesFiltered(t *testing.T) {
+	r := NewHashRing()
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+	r.Add("localhost:7002", 20)
+
+	excluded := "localhost:7001"
+	nodes := r.GetNodesFiltered([]byte("golang"), 2, func(n Node) bool {
+		return n.GetHost() != excluded
+	})
+
+	assert.Len(t, nodes, 2)
+	for _, n := range nodes {
+		assert.NotEqual(t, excluded, n.GetHost())
+	}
+}
+
+// the same behavior should hold for the Rendezvous ring implementation.
+func TestRendezvousGetNodesDistinctHosts(t *testing.T) {
+	r := NewRendezvousRing()
+	for i := 0; i < 5; i++ {
+		r.Add(fmt.Sprint("localhost:", 7000+i), 20)
+	}
+
+	nodes := r.GetNodes([]byte("golang"), 3)
+	assert.Len(t, nodes, 3)
+
+	seen := make(map[string]bool)
+	for _, n := range nodes {
+		assert.False(t, seen[n.GetHost()])
+		seen[n.GetHost()] = true
+	}
+
+	assert.Equal(t, r.GetNode([]byte("golang")).GetHost(), nodes[0].GetHost())
+}
@@ -6,7 +6,7 @@ package ring
 // package imports
 import (
 	"fmt"
-	"hash/fnv"
+	"io"
 	"sort"
 	"sync"
 
@@ -32,18 +32,64 @@ type Node interface {
 
 // Ring is the main interface for this package. It comprises of methods used to hash keys into buckets which
 // will be evenly divided among all virtual nodes in the ring.
-// All values are hashed using the FNV algorithm into an unsigned 64-bit integer. The Jump Hash
-// algorithm then determines which bucket a hash falls into.
+// In the ring built by NewHashRing, values are hashed into an unsigned 64-bit integer using a
+// configurable Hasher64 (FNV-1a by default - see NewHashRingWithOptions). The Jump Hash
+// algorithm then determines which bucket a hash falls into. NewRendezvousRing instead hashes
+// with FNV-1a and scores every host with Rendezvous (HRW) hashing.
 type Ring interface {
 
 	// Adds a host to the ring. The first arg
 	Add(host string, size int)
 
+	// Removes a host (and its virtual nodes) from the ring.
+	Remove(host string)
+
+	// Updates a host's virtual node count, adding or removing virtual nodes
+	// as needed to match the new size.
+	Update(host string, size int)
+
 	// Returns the size of the ring. Virtual nodes are included.
 	Size() int
 
 	// Returns a node for the given bucket number
 	GetNode(data []byte) Node
+
+	// GetNodeString is equivalent to GetNode([]byte(key)) but avoids the
+	// string-to-[]byte conversion when the ring's key hasher supports it.
+	GetNodeString(key string) Node
+
+	// GetNodeReader hashes the remaining contents of r and returns its node.
+	// With the default FNV-1a key hasher the data is streamed rather than
+	// held in memory at once; a custom Hasher64 takes a []byte, so with one
+	// configured the whole of r is read into memory first.
+	GetNodeReader(r io.Reader) (Node, error)
+
+	// Returns up to n distinct physical hosts that own data. The Jump Hash
+	// ring walks its node list from the primary bucket; the Rendezvous ring
+	// ranks hosts by descending HRW weight. Used for replication, where a
+	// key needs to live on several hosts rather than just its primary.
+	GetNodes(data []byte, n int) []Node
+
+	// Like GetNodes, but skips any virtual node for which filter returns
+	// false. Useful for rack/zone-aware placement, e.g. excluding hosts that
+	// already share a rack with a host already selected.
+	GetNodesFiltered(data []byte, n int, filter func(Node) bool) []Node
+
+	// Inc records one unit of load against host, for use with GetNodeBounded.
+	Inc(host string)
+
+	// Dec removes one unit of load previously recorded against host.
+	Dec(host string)
+
+	// Loads returns a snapshot of the load recorded against every host.
+	Loads() map[string]int64
+
+	// GetNodeBounded is a bounded-load variant of GetNode: if the primary
+	// host's load is at or above a cap derived from the ring's average load
+	// and factor, it moves on (along the node list for the Jump Hash ring, down
+	// the HRW ranking for the Rendezvous ring) until it finds a host under
+	// that cap.
+	GetNodeBounded(data []byte, factor float64) Node
 }
 
 // --------------------
@@ -85,7 +131,18 @@ type nodeList []node
 
 type hashRing struct {
 	sync.RWMutex
-	nodes nodeList
+	nodes      nodeList
+	keyHasher  Hasher64
+	nodeHasher Hasher64
+
+	// defaultKeyHasher is true when no WithKeyHasher option was given (so
+	// keyHasher is the default FNV1aHash), letting GetNodeString/GetNodeReader
+	// use fnvHashString/fnvHashReader instead of copying the key into a
+	// []byte first.
+	defaultKeyHasher bool
+
+	loadMu sync.Mutex
+	loads  map[string]int64
 }
 
 // Len is the number of elements in the collection.
@@ -113,7 +170,11 @@ func (h nodeList) sort() {
 func (h *hashRing) Add(host string, size int) {
 	h.Lock()
 	defer h.Unlock()
-	var hasher = fnv.New64a()
+	h.addLocked(host, size)
+}
+
+// addLocked performs the actual insertion. Callers must hold h's write lock.
+func (h *hashRing) addLocked(host string, size int) {
 	hlen := len(h.nodes)
 	cap := hlen + size
 
@@ -126,25 +187,72 @@ func (h *hashRing) Add(host string, size int) {
 	for i := hlen; i < cap; i++ {
 		// hash: 0:localhost:7000:0
 		// adding the index at the start and end seemed to give better distribution...
-		hasher.Write([]byte(fmt.Sprint(i, ":", host, ":", i)))
-
-		// hash value
-		value := hasher.Sum64()
+		value := h.nodeHasher([]byte(fmt.Sprint(i, ":", host, ":", i)))
 
 		// create node
 		n := node{hash: value, host: host, size: size}
 
 		// insert node
 		h.nodes[i] = n
-
-		// reset hash
-		hasher.Reset()
 	}
 
 	// sort nodes around ring based on hash
 	h.nodes.sort()
 }
 
+// Remove drops a host (and its virtual nodes) from the ring.
+//
+// Movement guarantee: Jump Hash only ever remaps keys out of the
+// highest-indexed bucket when the bucket count shrinks by one - every other
+// bucket keeps its existing key assignment. Remove leans on that property by
+// swapping each of the host's virtual nodes with whatever currently occupies
+// the last slot before truncating, equivalent to shrinking the ring one
+// bucket at a time. That relocates the former top bucket into the removed
+// virtual node's slot, so keys that used to resolve to either one can move;
+// every other slot is untouched. In practice this costs on the order of
+// twice the removed host's share of the ring - far less than a naive removal
+// that shifts everything after it, but more than the theoretical 1/N
+// minimum, since each removed bucket also displaces one surviving bucket's
+// worth of keys.
+func (h *hashRing) Remove(host string) {
+	h.Lock()
+	defer h.Unlock()
+	h.removeLocked(host)
+
+	h.loadMu.Lock()
+	delete(h.loads, host)
+	h.loadMu.Unlock()
+}
+
+// removeLocked performs the swap-to-tail removal described on Remove.
+// Callers must hold h's write lock.
+func (h *hashRing) removeLocked(host string) {
+	last := len(h.nodes)
+	for i := 0; i < last; {
+		if h.nodes[i].host == host {
+			last--
+			h.nodes[i], h.nodes[last] = h.nodes[last], h.nodes[i]
+			continue
+		}
+		i++
+	}
+	h.nodes = h.nodes[:last]
+}
+
+// Update changes the number of virtual nodes registered for host, adding or
+// removing virtual nodes to reach the requested size. It is implemented as a
+// removal of the host's current virtual nodes followed by a fresh Add. The
+// Add step re-sorts the whole node list by hash, which shifts the slots of
+// other hosts' virtual nodes, so unlike Remove alone Update does not keep
+// every other slot in place and can move keys between hosts other than the
+// one being updated.
+func (h *hashRing) Update(host string, size int) {
+	h.Lock()
+	defer h.Unlock()
+	h.removeLocked(host)
+	h.addLocked(host, size)
+}
+
 // returns the size of the ring
 func (h *hashRing) Size() int {
 	h.RLock()
@@ -156,31 +264,182 @@ func (h *hashRing) Size() int {
 func (h *hashRing) GetNode(data []byte) Node {
 	h.RLock()
 	defer h.RUnlock()
-	return h.nodes[h.calculateJumpHash(hash(data))]
+	return h.nodes[h.calculateJumpHash(h.keyHasher(data))]
 }
 
-// returns a particular index
-func (h *hashRing) calculateJumpHash(hash uint64) int {
+// GetNodeString is equivalent to GetNode([]byte(key)) but avoids the
+// string-to-[]byte conversion when the ring is using the default FNV-1a
+// hasher. See the Ring interface for details.
+func (h *hashRing) GetNodeString(key string) Node {
+	h.RLock()
+	defer h.RUnlock()
+
+	var keyHash uint64
+	if h.defaultKeyHasher {
+		keyHash = fnvHashString(key)
+	} else {
+		keyHash = h.keyHasher([]byte(key))
+	}
+	return h.nodes[h.calculateJumpHash(keyHash)]
+}
+
+// GetNodeReader hashes the remaining contents of r and returns its node. See
+// the Ring interface for details.
+func (h *hashRing) GetNodeReader(r io.Reader) (Node, error) {
+	h.RLock()
+	defer h.RUnlock()
+
+	var keyHash uint64
+	if h.defaultKeyHasher {
+		hashed, err := fnvHashReader(r)
+		if err != nil {
+			return nil, err
+		}
+		keyHash = hashed
+	} else {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		keyHash = h.keyHasher(data)
+	}
+	return h.nodes[h.calculateJumpHash(keyHash)], nil
+}
+
+// GetNodes returns up to n distinct physical hosts that own data. See the
+// Ring interface for details.
+func (h *hashRing) GetNodes(data []byte, n int) []Node {
+	return h.GetNodesFiltered(data, n, nil)
+}
+
+// GetNodesFiltered returns up to n distinct physical hosts that own data and
+// satisfy filter. See the Ring interface for details.
+func (h *hashRing) GetNodesFiltered(data []byte, n int, filter func(Node) bool) []Node {
 	h.RLock()
 	defer h.RUnlock()
+
+	size := len(h.nodes)
+	if size == 0 || n <= 0 {
+		return nil
+	}
+
+	primary := h.calculateJumpHash(h.keyHasher(data))
+	seen := make(map[string]bool, n)
+	nodes := make([]Node, 0, n)
+
+	for i := 0; i < size && len(nodes) < n; i++ {
+		candidate := h.nodes[(primary+i)%size]
+		if seen[candidate.host] {
+			continue
+		}
+		if filter != nil && !filter(candidate) {
+			continue
+		}
+		seen[candidate.host] = true
+		nodes = append(nodes, candidate)
+	}
+	return nodes
+}
+
+// Inc records one unit of load against host, for use with GetNodeBounded.
+func (h *hashRing) Inc(host string) {
+	h.loadMu.Lock()
+	defer h.loadMu.Unlock()
+	h.loads[host]++
+}
+
+// Dec removes one unit of load previously recorded against host.
+func (h *hashRing) Dec(host string) {
+	h.loadMu.Lock()
+	defer h.loadMu.Unlock()
+	if h.loads[host] > 0 {
+		h.loads[host]--
+	}
+}
+
+// Loads returns a snapshot of the load recorded against every host.
+func (h *hashRing) Loads() map[string]int64 {
+	h.loadMu.Lock()
+	defer h.loadMu.Unlock()
+
+	loads := make(map[string]int64, len(h.loads))
+	for host, load := range h.loads {
+		loads[host] = load
+	}
+	return loads
+}
+
+// GetNodeBounded is a bounded-load variant of GetNode. See the Ring
+// interface for details.
+func (h *hashRing) GetNodeBounded(data []byte, factor float64) Node {
+	h.RLock()
+	defer h.RUnlock()
+
+	size := len(h.nodes)
+	if size == 0 {
+		return nil
+	}
+
+	hosts := make(map[string]bool)
+	for _, n := range h.nodes {
+		hosts[n.host] = true
+	}
+
+	h.loadMu.Lock()
+	var total int64
+	for _, load := range h.loads {
+		total += load
+	}
+	h.loadMu.Unlock()
+
+	cap := boundedCapacity(total, len(hosts), factor)
+	primary := h.calculateJumpHash(h.keyHasher(data))
+
+	for i := 0; i < size; i++ {
+		candidate := h.nodes[(primary+i)%size]
+
+		h.loadMu.Lock()
+		load := h.loads[candidate.host]
+		h.loadMu.Unlock()
+
+		if load < cap {
+			return candidate
+		}
+	}
+
+	// every host is at or above cap; fall back to the primary
+	return h.nodes[primary]
+}
+
+// calculateJumpHash returns the bucket for hash among len(h.nodes) buckets.
+// Callers must already hold at least a read lock on h - calculateJumpHash
+// used to take its own RLock, which double-locked (and could deadlock
+// against a pending writer) every time a caller like GetNode held the lock
+// across the call.
+func (h *hashRing) calculateJumpHash(hash uint64) int {
 	return int(jump.Hash(hash, len(h.nodes)))
 }
 
-// NewHashRing creates a new hash ring.
+// NewHashRing creates a new hash ring using the default FNV-1a hasher. It is
+// equivalent to NewHashRingWithOptions() with no options.
 func NewHashRing() Ring {
-	return &hashRing{nodes: make([]node, 0, 16)}
+	return NewHashRingWithOptions()
 }
 
-// CalculateBucketGivenSize calculates a Jump hash for the key provided
+// CalculateBucketGivenSize calculates a Jump hash for the key provided, using
+// the default FNV-1a hasher.
 func CalculateBucketGivenSize(data []byte, size int) int {
-	var hasher = fnv.New64a()
-	hasher.Write(data)
-	return int(jump.Hash(hasher.Sum64(), size))
+	return CalculateBucketGivenSizeWithHasher(data, size, FNV1aHash)
+}
+
+// CalculateBucketGivenSizeWithHasher calculates a Jump hash for the key
+// provided using the supplied Hasher64.
+func CalculateBucketGivenSizeWithHasher(data []byte, size int, hasher Hasher64) int {
+	return int(jump.Hash(hasher(data), size))
 }
 
-// FNV-64a hash
+// hash is the package-wide default Hasher64, used anywhere a ring
+// implementation isn't configured with its own.
 func hash(data []byte) uint64 {
-	var hasher = fnv.New64a()
-	hasher.Write(data)
-	return hasher.Sum64()
+	return FNV1aHash(data)
 }
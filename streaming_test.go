@@ -0,0 +1,93 @@
+package ring
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// GetNodeString must agree with GetNode([]byte(key)) for the default hasher.
+func TestHashRingGetNodeString(t *testing.T) {
+	r := NewHashRing()
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+	r.Add("localhost:7002", 20)
+
+	key := "golang"
+	assert.Equal(t, r.GetNode([]byte(key)), r.GetNodeString(key))
+}
+
+// GetNodeString must also agree with GetNode when using a non-default hasher.
+func TestHashRingGetNodeStringCustomHasher(t *testing.T) {
+	r := NewHashRingWithOptions(WithKeyHasher(XXHash), WithNodeHasher(XXHash))
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+	r.Add("localhost:7002", 20)
+
+	key := "golang"
+	assert.Equal(t, r.GetNode([]byte(key)), r.GetNodeString(key))
+}
+
+// GetNodeReader must agree with GetNode given the same bytes.
+func TestHashRingGetNodeReader(t *testing.T) {
+	r := NewHashRing()
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+	r.Add("localhost:7002", 20)
+
+	data := []byte("golang")
+	expected := r.GetNode(data)
+
+	actual, err := r.GetNodeReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+// the Rendezvous ring should honor the same streaming contract.
+func TestRendezvousGetNodeStringAndReader(t *testing.T) {
+	r := NewRendezvousRing()
+	r.Add("localhost:7000", 20)
+	r.Add("localhost:7001", 20)
+	r.Add("localhost:7002", 20)
+
+	data := []byte("golang")
+	expected := r.GetNode(data)
+
+	assert.Equal(t, expected, r.GetNodeString("golang"))
+
+	actual, err := r.GetNodeReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+// reports allocations on the default FNV-1a GetNodeString path. Hashing the
+// key does not allocate; returning the node as a Node interface value costs
+// one allocation per call.
+func BenchmarkGetNodeString_Allocs(b *testing.B) {
+	r := NewHashRing()
+	for i := 0; i < 5; i++ {
+		r.Add(fmt.Sprint("localhost:", 7000+i), 5)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		r.GetNodeString("golang")
+	}
+}
+
+func BenchmarkGetNode_Allocs(b *testing.B) {
+	r := NewHashRing()
+	for i := 0; i < 5; i++ {
+		r.Add(fmt.Sprint("localhost:", 7000+i), 5)
+	}
+	data := []byte("golang")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		r.GetNode(data)
+	}
+}